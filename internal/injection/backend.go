@@ -0,0 +1,23 @@
+package injection
+
+import (
+	"context"
+	"time"
+)
+
+// Backend is a single injection strategy (typing, clipboard, an external
+// program, ...) that NewInjector chains together based on Config.Backends.
+type Backend interface {
+	// Name identifies the backend for logging and timeout lookup.
+	Name() string
+	// Inject delivers text using this backend's mechanism, aborting if
+	// timeout elapses.
+	Inject(ctx context.Context, text string, timeout time.Duration) error
+}
+
+// selectionWriter is implemented by backends that can target a specific
+// clipboard selection ("clipboard" or "primary") rather than always
+// writing the default one.
+type selectionWriter interface {
+	InjectSelection(ctx context.Context, selection string, text string, timeout time.Duration) error
+}
@@ -0,0 +1,103 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// clipboardBackend copies text onto the system clipboard using wl-copy
+// (Wayland) or xclip (X11), whichever is available, and can target either
+// the CLIPBOARD or PRIMARY X11/Wayland selection.
+type clipboardBackend struct{}
+
+// NewClipboardBackend builds a Backend that copies text onto the system
+// clipboard.
+func NewClipboardBackend() Backend {
+	return &clipboardBackend{}
+}
+
+func (b *clipboardBackend) Name() string {
+	return "clipboard"
+}
+
+func (b *clipboardBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	return b.InjectSelection(ctx, "clipboard", text, timeout)
+}
+
+// InjectSelection writes text to the given selection ("clipboard" or
+// "primary"). It's the dispatch point the injector's Inject uses for
+// Config.ClipboardSelections entries that name a real X11/Wayland
+// selection rather than an in-memory named register.
+func (b *clipboardBackend) InjectSelection(ctx context.Context, selection string, text string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path, args, err := clipboardWriteCommand(selection)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader([]byte(text))
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("clipboard: %s failed for %s: %w: %s", path, selection, err, stderr.String())
+	}
+
+	return nil
+}
+
+// Read returns the current plain-text contents of the CLIPBOARD selection.
+func (b *clipboardBackend) Read(ctx context.Context, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path, args, err := clipboardReadCommand()
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("clipboard: %s failed: %w: %s", path, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+func clipboardWriteCommand(selection string) (string, []string, error) {
+	if path, err := exec.LookPath("wl-copy"); err == nil {
+		var args []string
+		if selection == "primary" {
+			args = append(args, "--primary")
+		}
+		return path, args, nil
+	}
+	if path, err := exec.LookPath("xclip"); err == nil {
+		if selection == "" {
+			selection = "clipboard"
+		}
+		return path, []string{"-selection", selection}, nil
+	}
+	return "", nil, fmt.Errorf("clipboard: neither wl-copy nor xclip found on $PATH")
+}
+
+func clipboardReadCommand() (string, []string, error) {
+	if path, err := exec.LookPath("wl-paste"); err == nil {
+		return path, []string{"--no-newline"}, nil
+	}
+	if path, err := exec.LookPath("xclip"); err == nil {
+		return path, []string{"-selection", "clipboard", "-o"}, nil
+	}
+	return "", nil, fmt.Errorf("clipboard: neither wl-paste nor xclip found on $PATH")
+}
@@ -0,0 +1,63 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// execBackend shells out to a user-supplied program found on $PATH, piping
+// the transcribed text to its stdin. It lets users plug in arbitrary
+// injection tools (IBus, AT-SPI, wlrctl, a custom Hyprland dispatcher, an
+// SSH-forwarded injector) without compiling them into hyprvoice, mirroring
+// the micro-clip external-command pattern.
+type execBackend struct {
+	program string
+	mode    string
+}
+
+// NewExecBackend builds a Backend that execs program with mode (e.g. "paste"
+// or "type") as argv[1] and the text to inject on stdin.
+func NewExecBackend(program, mode string) Backend {
+	return &execBackend{program: program, mode: mode}
+}
+
+func (b *execBackend) Name() string {
+	return "exec:" + b.program
+}
+
+// execLookPath and execCommandContext are seams for tests to substitute a
+// fake helper process, following the standard os/exec TestHelperProcess
+// pattern.
+var (
+	execLookPath       = exec.LookPath
+	execCommandContext = exec.CommandContext
+)
+
+func (b *execBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path, err := execLookPath(b.program)
+	if err != nil {
+		return fmt.Errorf("exec backend: %q not found on $PATH: %w", b.program, err)
+	}
+
+	cmd := execCommandContext(ctx, path, b.mode)
+	cmd.Stdin = strings.NewReader(text)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("exec backend %q failed: %w: %s", b.program, err, strings.TrimSpace(stderr.String()))
+		}
+		return fmt.Errorf("exec backend %q failed: %w", b.program, err)
+	}
+
+	return nil
+}
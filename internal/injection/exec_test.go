@@ -0,0 +1,94 @@
+package injection
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHelperProcess isn't a real test; it's invoked as a subprocess by the
+// tests below, the standard approach for faking external commands in
+// os/exec-based code.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	mode := os.Args[len(os.Args)-1]
+	if mode == "fail" {
+		os.Stderr.WriteString("helper: boom\n")
+		os.Exit(1)
+	}
+
+	buf, _ := os.ReadFile("/dev/stdin")
+	if len(buf) == 0 {
+		os.Stderr.WriteString("helper: empty stdin\n")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func stubExecHelper(t *testing.T) {
+	t.Helper()
+	origLookPath, origCommandContext := execLookPath, execCommandContext
+	execLookPath = func(program string) (string, error) {
+		return program, nil
+	}
+	execCommandContext = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		cs := append([]string{"-test.run=TestHelperProcess", "--"}, args...)
+		cmd := exec.CommandContext(ctx, os.Args[0], cs...)
+		cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+		return cmd
+	}
+	t.Cleanup(func() {
+		execLookPath = origLookPath
+		execCommandContext = origCommandContext
+	})
+}
+
+// execHelperTimeout gives the TestHelperProcess re-exec room to start under
+// -race, where the race-instrumented binary alone can take over a second to
+// spin up; a tighter budget makes these tests flaky for reasons unrelated to
+// the code under test.
+const execHelperTimeout = 5 * time.Second
+
+func TestExecBackendInjectSuccess(t *testing.T) {
+	stubExecHelper(t)
+	backend := NewExecBackend("hyprvoice-inject", "type")
+
+	if err := backend.Inject(context.Background(), "hello world", execHelperTimeout); err != nil {
+		t.Fatalf("Inject() error = %v, want nil", err)
+	}
+}
+
+func TestExecBackendInjectFailurePropagatesStderr(t *testing.T) {
+	stubExecHelper(t)
+	backend := NewExecBackend("hyprvoice-inject", "fail")
+
+	err := backend.Inject(context.Background(), "hello world", execHelperTimeout)
+	if err == nil {
+		t.Fatal("Inject() error = nil, want failure")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Inject() error = %v, want stderr propagated, got %v", err, err)
+	}
+}
+
+func TestExecBackendName(t *testing.T) {
+	backend := NewExecBackend("hyprvoice-inject", "paste")
+	if got, want := backend.Name(), "exec:hyprvoice-inject"; got != want {
+		t.Fatalf("Name() = %q, want %q", got, want)
+	}
+}
+
+func TestExecBackendProgramNotFound(t *testing.T) {
+	backend := NewExecBackend("hyprvoice-inject-does-not-exist", "type")
+	err := backend.Inject(context.Background(), "hello", execHelperTimeout)
+	if err == nil {
+		t.Fatal("Inject() error = nil, want not-found error")
+	}
+}
@@ -4,23 +4,69 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
 type Injector interface {
 	Inject(ctx context.Context, text string) error
+	// Flush forces any pending clipboard restore to run immediately,
+	// bypassing ClipboardTTL. Callers (e.g. the daemon on shutdown) use
+	// this so PreserveClipboard doesn't leave stale contents behind.
+	Flush(ctx context.Context) error
+	// GetRegister returns the text last injected into the named register
+	// (a Config.ClipboardSelections entry that isn't "clipboard" or
+	// "primary"), so it can be replayed without re-recording. Exposing this
+	// over a `hyprvoicectl paste --register=<name>` command is the job of
+	// whatever daemon/IPC layer embeds this package; this tree has no
+	// cmd/hyprvoicectl or socket server yet, so that wiring doesn't exist
+	// here.
+	GetRegister(name string) (string, bool)
 }
 
 type Config struct {
-	Backends         []string      // Ordered list: "ydotool", "wtype", "clipboard"
+	Backends         []string      // Ordered list: "ydotool", "wtype", "clipboard", "osc52", "exec:<program>"
 	YdotoolTimeout   time.Duration // Timeout for ydotool commands
 	WtypeTimeout     time.Duration // Timeout for wtype commands
 	ClipboardTimeout time.Duration // Timeout for clipboard operations
+	ExecTimeout      time.Duration // Timeout for exec:<program> commands
+	ExecMode         string        // Mode passed as argv[1] to exec:<program> (default "type")
+
+	PreserveClipboard bool          // Stash and restore the clipboard's prior contents around Inject
+	ClipboardTTL      time.Duration // How long to leave injected text on the clipboard before restoring
+	ClearOnRestore    bool          // If the stashed contents were empty, restore an empty clipboard instead of skipping
+
+	Osc52Timeout  time.Duration // Timeout for osc52 writes
+	Osc52MaxBytes int           // Max base64 payload bytes per OSC 52 write (0 uses the default, ~74KB); larger payloads are rejected
+
+	// ClipboardSelections lists every selection/register Inject writes to:
+	// "clipboard", "primary", or a named in-memory register (e.g.
+	// "hyprvoice:last"). Defaults to ["clipboard"].
+	ClipboardSelections []string
 }
 
+// clipboardReader is implemented by backends that can read back the current
+// clipboard contents, used to stash/compare around a preserved injection.
+type clipboardReader interface {
+	Read(ctx context.Context, timeout time.Duration) (string, error)
+}
+
+// newClipboardBackend is a seam so tests can substitute a fake clipboard.
+var newClipboardBackend = NewClipboardBackend
+
 type injector struct {
 	config   Config
 	backends []Backend
+
+	restoreMu     sync.Mutex
+	restoreGen    uint64
+	restoreCancel context.CancelFunc
+	restoreNow    func(ctx context.Context)
+
+	registersMu sync.Mutex
+	registers   map[string]string
 }
 
 func NewInjector(config Config) Injector {
@@ -33,16 +79,26 @@ func NewInjector(config Config) Injector {
 		case "wtype":
 			backends = append(backends, NewWtypeBackend())
 		case "clipboard":
-			backends = append(backends, NewClipboardBackend())
+			backends = append(backends, newClipboardBackend())
+		case "osc52":
+			backends = append(backends, NewOsc52Backend(config.Osc52MaxBytes))
 		default:
-			log.Printf("Injection: unknown backend %q, skipping", name)
+			if program, ok := strings.CutPrefix(name, "exec:"); ok && program != "" {
+				mode := config.ExecMode
+				if mode == "" {
+					mode = "type"
+				}
+				backends = append(backends, NewExecBackend(program, mode))
+			} else {
+				log.Printf("Injection: unknown backend %q, skipping", name)
+			}
 		}
 	}
 
 	// Default to clipboard if no valid backends
 	if len(backends) == 0 {
 		log.Printf("Injection: no valid backends configured, defaulting to clipboard")
-		backends = append(backends, NewClipboardBackend())
+		backends = append(backends, newClipboardBackend())
 	}
 
 	return &injector{
@@ -56,13 +112,42 @@ func (i *injector) Inject(ctx context.Context, text string) error {
 		return fmt.Errorf("cannot inject empty text")
 	}
 
-	// Always copy to clipboard first (best effort, don't fail if clipboard fails)
-	clipboardBackend := NewClipboardBackend()
-	clipboardErr := clipboardBackend.Inject(ctx, text, i.config.ClipboardTimeout)
-	if clipboardErr != nil {
-		log.Printf("Injection: clipboard copy failed (will continue with other backends): %v", clipboardErr)
-	} else {
-		log.Printf("Injection: text copied to clipboard")
+	// ClipboardSelections is authoritative: an empty list defaults to
+	// ["clipboard"], but an explicit list that omits "clipboard" (e.g.
+	// ["primary"]) means the CLIPBOARD selection is intentionally left
+	// untouched.
+	writeClipboard := len(i.config.ClipboardSelections) == 0 || slices.Contains(i.config.ClipboardSelections, "clipboard")
+
+	clipboardBackend := newClipboardBackend()
+
+	var stashed string
+	var hadStash bool
+	clipboardErr := fmt.Errorf("clipboard: not in Config.ClipboardSelections, skipped")
+	if writeClipboard {
+		if i.config.PreserveClipboard {
+			if reader, ok := clipboardBackend.(clipboardReader); ok {
+				prev, err := reader.Read(ctx, i.config.ClipboardTimeout)
+				if err != nil {
+					log.Printf("Injection: could not read clipboard to preserve it: %v", err)
+				} else {
+					stashed, hadStash = prev, true
+				}
+			}
+		}
+
+		clipboardErr = clipboardBackend.Inject(ctx, text, i.config.ClipboardTimeout)
+		if clipboardErr != nil {
+			log.Printf("Injection: clipboard copy failed (will continue with other backends): %v", clipboardErr)
+		} else {
+			log.Printf("Injection: text copied to clipboard")
+		}
+	}
+
+	i.writeExtraSelections(ctx, clipboardBackend, text)
+
+	typed := make(chan struct{})
+	if writeClipboard && i.config.PreserveClipboard && clipboardErr == nil {
+		i.scheduleRestore(text, stashed, hadStash, typed)
 	}
 
 	// Try each backend in order for typing
@@ -76,6 +161,7 @@ func (i *injector) Inject(ctx context.Context, text string) error {
 		err := backend.Inject(ctx, text, timeout)
 		if err == nil {
 			log.Printf("Injection: success via %s", backend.Name())
+			close(typed)
 			return nil
 		}
 		log.Printf("Injection: %s failed: %v, trying next backend", backend.Name(), err)
@@ -95,6 +181,145 @@ func (i *injector) Inject(ctx context.Context, text string) error {
 	return nil
 }
 
+// writeExtraSelections writes text to every Config.ClipboardSelections
+// entry beyond the default "clipboard" one: "primary" goes to the PRIMARY
+// X11/Wayland selection, anything else is treated as a named in-memory
+// register. These are best-effort and don't affect Inject's overall
+// success/failure.
+func (i *injector) writeExtraSelections(ctx context.Context, clipboardBackend Backend, text string) {
+	for _, selection := range i.config.ClipboardSelections {
+		switch selection {
+		case "", "clipboard":
+			// Already handled above.
+		case "primary":
+			sw, ok := clipboardBackend.(selectionWriter)
+			if !ok {
+				log.Printf("Injection: clipboard backend doesn't support the primary selection, skipping")
+				continue
+			}
+			if err := sw.InjectSelection(ctx, "primary", text, i.config.ClipboardTimeout); err != nil {
+				log.Printf("Injection: primary selection copy failed: %v", err)
+			}
+		default:
+			i.setRegister(selection, text)
+		}
+	}
+}
+
+func (i *injector) setRegister(name, value string) {
+	i.registersMu.Lock()
+	defer i.registersMu.Unlock()
+	if i.registers == nil {
+		i.registers = make(map[string]string)
+	}
+	i.registers[name] = value
+}
+
+// GetRegister returns the text last injected into the named register.
+func (i *injector) GetRegister(name string) (string, bool) {
+	i.registersMu.Lock()
+	defer i.registersMu.Unlock()
+	v, ok := i.registers[name]
+	return v, ok
+}
+
+// scheduleRestore starts a goroutine that restores (or clears) the
+// clipboard once typed fires or ClipboardTTL elapses, whichever comes
+// first. It supersedes any restore already pending from an earlier Inject.
+// restoreCtx is deliberately independent of the triggering Inject call's
+// ctx: callers commonly scope that ctx to the Inject call itself (e.g.
+// context.WithTimeout before calling Inject), which would cancel the
+// restore timer the instant Inject returns. Flush/shutdown cancels it
+// explicitly instead.
+func (i *injector) scheduleRestore(written, stashed string, hadStash bool, typed <-chan struct{}) {
+	restoreCtx, cancel := context.WithCancel(context.Background())
+	restore := func(ctx context.Context) { i.restoreClipboard(ctx, written, stashed, hadStash) }
+
+	i.restoreMu.Lock()
+	if i.restoreCancel != nil {
+		i.restoreCancel()
+	}
+	i.restoreGen++
+	gen := i.restoreGen
+	i.restoreCancel = cancel
+	i.restoreNow = restore
+	i.restoreMu.Unlock()
+
+	go func() {
+		timer := time.NewTimer(i.config.ClipboardTTL)
+		defer timer.Stop()
+
+		select {
+		case <-typed:
+		case <-timer.C:
+		case <-restoreCtx.Done():
+			return
+		}
+
+		i.restoreMu.Lock()
+		if i.restoreGen != gen {
+			// Already flushed or superseded by a newer Inject.
+			i.restoreMu.Unlock()
+			return
+		}
+		i.restoreCancel = nil
+		i.restoreNow = nil
+		i.restoreMu.Unlock()
+
+		restore(context.Background())
+	}()
+}
+
+func (i *injector) restoreClipboard(ctx context.Context, written, stashed string, hadStash bool) {
+	clipboardBackend := newClipboardBackend()
+
+	reader, ok := clipboardBackend.(clipboardReader)
+	if !ok {
+		return
+	}
+	current, err := reader.Read(ctx, i.config.ClipboardTimeout)
+	if err != nil {
+		log.Printf("Injection: clipboard restore skipped, failed to read clipboard: %v", err)
+		return
+	}
+	if current != written {
+		log.Printf("Injection: clipboard changed externally, skipping restore")
+		return
+	}
+
+	if !hadStash || stashed == "" {
+		if !i.config.ClearOnRestore {
+			log.Printf("Injection: nothing to restore, leaving clipboard as-is")
+			return
+		}
+		stashed = ""
+	}
+
+	if err := clipboardBackend.Inject(ctx, stashed, i.config.ClipboardTimeout); err != nil {
+		log.Printf("Injection: clipboard restore failed: %v", err)
+	}
+}
+
+// Flush forces any pending restore to run now instead of waiting for
+// ClipboardTTL, so a shutting-down daemon doesn't leave injected text
+// sitting on the clipboard.
+func (i *injector) Flush(ctx context.Context) error {
+	i.restoreMu.Lock()
+	cancel := i.restoreCancel
+	restore := i.restoreNow
+	i.restoreCancel = nil
+	i.restoreNow = nil
+	i.restoreMu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if restore != nil {
+		restore(ctx)
+	}
+	return nil
+}
+
 func (i *injector) getTimeout(backendName string) time.Duration {
 	switch backendName {
 	case "ydotool":
@@ -103,7 +328,12 @@ func (i *injector) getTimeout(backendName string) time.Duration {
 		return i.config.WtypeTimeout
 	case "clipboard":
 		return i.config.ClipboardTimeout
+	case "osc52":
+		return i.config.Osc52Timeout
 	default:
+		if strings.HasPrefix(backendName, "exec:") {
+			return i.config.ExecTimeout
+		}
 		return 5 * time.Second
 	}
 }
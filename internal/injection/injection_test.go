@@ -0,0 +1,263 @@
+package injection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClipboard is an in-memory stand-in for the real clipboard backend,
+// swapped in via newClipboardBackend for tests.
+type fakeClipboard struct {
+	mu      sync.Mutex
+	buf     string
+	primary string
+}
+
+func (c *fakeClipboard) Name() string { return "clipboard" }
+
+func (c *fakeClipboard) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	return c.InjectSelection(ctx, "clipboard", text, timeout)
+}
+
+func (c *fakeClipboard) InjectSelection(ctx context.Context, selection string, text string, timeout time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if selection == "primary" {
+		c.primary = text
+	} else {
+		c.buf = text
+	}
+	return nil
+}
+
+func (c *fakeClipboard) Read(ctx context.Context, timeout time.Duration) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf, nil
+}
+
+func withFakeClipboard(t *testing.T, initial string) *fakeClipboard {
+	t.Helper()
+	clip := &fakeClipboard{buf: initial}
+	orig := newClipboardBackend
+	newClipboardBackend = func() Backend { return clip }
+	t.Cleanup(func() { newClipboardBackend = orig })
+	return clip
+}
+
+// succeedingBackend always reports success without touching the clipboard.
+type succeedingBackend struct{ name string }
+
+func (b succeedingBackend) Name() string { return b.name }
+func (b succeedingBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	return nil
+}
+
+// failingBackend always reports failure, leaving any pending clipboard
+// restore to fire on ClipboardTTL (or a manual Flush) instead of on typing
+// success.
+type failingBackend struct{ name string }
+
+func (b failingBackend) Name() string { return b.name }
+func (b failingBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	return errInjectFailed
+}
+
+var errInjectFailed = fmt.Errorf("injection failed")
+
+func TestInjectPreservesAndRestoresClipboard(t *testing.T) {
+	clip := withFakeClipboard(t, "previous contents")
+
+	inj := &injector{
+		config: Config{
+			PreserveClipboard: true,
+			ClipboardTTL:      time.Hour, // typing success should restore first
+			ClipboardTimeout:  time.Second,
+		},
+		backends: []Backend{succeedingBackend{name: "wtype"}},
+	}
+
+	if err := inj.Inject(context.Background(), "transcribed text"); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+
+	// Restore runs in a goroutine triggered by typing success; give it a
+	// moment to complete.
+	deadline := time.After(time.Second)
+	for {
+		clip.mu.Lock()
+		got := clip.buf
+		clip.mu.Unlock()
+		if got == "previous contents" {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("clipboard = %q, want restored to %q", got, "previous contents")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestInjectSkipsRestoreIfClipboardChangedExternally(t *testing.T) {
+	clip := withFakeClipboard(t, "previous contents")
+
+	inj := &injector{
+		config: Config{
+			PreserveClipboard: true,
+			ClipboardTTL:      time.Hour,
+			ClipboardTimeout:  time.Second,
+		},
+		backends: []Backend{failingBackend{name: "wtype"}},
+	}
+
+	// Typing fails, so the restore stays pending on ClipboardTTL/Flush
+	// rather than firing immediately.
+	_ = inj.Inject(context.Background(), "transcribed text")
+
+	// Simulate the user copying something else before the restore runs.
+	clip.mu.Lock()
+	clip.buf = "user copied this"
+	clip.mu.Unlock()
+
+	if err := inj.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	clip.mu.Lock()
+	defer clip.mu.Unlock()
+	if clip.buf != "user copied this" {
+		t.Fatalf("clipboard = %q, want untouched %q", clip.buf, "user copied this")
+	}
+}
+
+func TestInjectFlushClearsEmptyStashWhenConfigured(t *testing.T) {
+	clip := withFakeClipboard(t, "")
+
+	inj := &injector{
+		config: Config{
+			PreserveClipboard: true,
+			ClearOnRestore:    true,
+			ClipboardTTL:      time.Hour,
+			ClipboardTimeout:  time.Second,
+		},
+		backends: []Backend{failingBackend{name: "wtype"}},
+	}
+
+	_ = inj.Inject(context.Background(), "transcribed text")
+	if err := inj.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	clip.mu.Lock()
+	defer clip.mu.Unlock()
+	if clip.buf != "" {
+		t.Fatalf("clipboard = %q, want cleared", clip.buf)
+	}
+}
+
+func TestInjectWritesPrimarySelection(t *testing.T) {
+	clip := withFakeClipboard(t, "")
+
+	inj := &injector{
+		config: Config{
+			ClipboardTimeout:    time.Second,
+			ClipboardSelections: []string{"clipboard", "primary"},
+		},
+		backends: []Backend{succeedingBackend{name: "wtype"}},
+	}
+
+	if err := inj.Inject(context.Background(), "transcribed text"); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+
+	clip.mu.Lock()
+	defer clip.mu.Unlock()
+	if clip.buf != "transcribed text" {
+		t.Fatalf("clipboard selection = %q, want %q", clip.buf, "transcribed text")
+	}
+	if clip.primary != "transcribed text" {
+		t.Fatalf("primary selection = %q, want %q", clip.primary, "transcribed text")
+	}
+}
+
+func TestInjectSkipsClipboardWhenNotSelected(t *testing.T) {
+	clip := withFakeClipboard(t, "")
+
+	inj := &injector{
+		config: Config{
+			ClipboardTimeout:    time.Second,
+			ClipboardSelections: []string{"primary"},
+		},
+		backends: []Backend{succeedingBackend{name: "wtype"}},
+	}
+
+	if err := inj.Inject(context.Background(), "transcribed text"); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+
+	clip.mu.Lock()
+	defer clip.mu.Unlock()
+	if clip.buf != "" {
+		t.Fatalf("clipboard selection = %q, want untouched", clip.buf)
+	}
+	if clip.primary != "transcribed text" {
+		t.Fatalf("primary selection = %q, want %q", clip.primary, "transcribed text")
+	}
+}
+
+func TestInjectWritesAndReadsNamedRegister(t *testing.T) {
+	withFakeClipboard(t, "")
+
+	inj := &injector{
+		config: Config{
+			ClipboardTimeout:    time.Second,
+			ClipboardSelections: []string{"clipboard", "hyprvoice:last"},
+		},
+		backends: []Backend{succeedingBackend{name: "wtype"}},
+	}
+
+	if err := inj.Inject(context.Background(), "transcribed text"); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+
+	got, ok := inj.GetRegister("hyprvoice:last")
+	if !ok || got != "transcribed text" {
+		t.Fatalf("GetRegister() = (%q, %v), want (%q, true)", got, ok, "transcribed text")
+	}
+
+	if _, ok := inj.GetRegister("nonexistent"); ok {
+		t.Fatal("GetRegister() ok = true for unknown register, want false")
+	}
+}
+
+func TestNewInjectorBuildsExecBackendAndRoutesTimeout(t *testing.T) {
+	inj := NewInjector(Config{
+		Backends:    []string{"exec:hyprvoice-inject"},
+		ExecTimeout: 7 * time.Second,
+	}).(*injector)
+
+	if len(inj.backends) != 1 || inj.backends[0].Name() != "exec:hyprvoice-inject" {
+		t.Fatalf("backends = %+v, want a single exec:hyprvoice-inject backend", inj.backends)
+	}
+	if got := inj.getTimeout("exec:hyprvoice-inject"); got != 7*time.Second {
+		t.Fatalf("getTimeout(%q) = %v, want %v", "exec:hyprvoice-inject", got, 7*time.Second)
+	}
+}
+
+func TestNewInjectorBuildsOsc52BackendAndRoutesTimeout(t *testing.T) {
+	inj := NewInjector(Config{
+		Backends:     []string{"osc52"},
+		Osc52Timeout: 3 * time.Second,
+	}).(*injector)
+
+	if len(inj.backends) != 1 || inj.backends[0].Name() != "osc52" {
+		t.Fatalf("backends = %+v, want a single osc52 backend", inj.backends)
+	}
+	if got := inj.getTimeout("osc52"); got != 3*time.Second {
+		t.Fatalf("getTimeout(%q) = %v, want %v", "osc52", got, 3*time.Second)
+	}
+}
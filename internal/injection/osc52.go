@@ -0,0 +1,94 @@
+package injection
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	osc52Prefix = "\x1b]52;c;"
+	osc52Suffix = "\x1b\\"
+
+	// defaultOsc52MaxBytes is the base64 payload size many terminals
+	// (tmux in particular) truncate above, roughly 74KB.
+	defaultOsc52MaxBytes = 74 * 1024
+)
+
+// osc52Backend injects text by writing an OSC 52 "set clipboard" escape
+// sequence to the controlling terminal, giving a working clipboard path
+// inside tmux/alacritty/kitty/foot and over SSH where ydotool/wtype
+// aren't reachable.
+//
+// There is no standard way to split a single OSC 52 payload across
+// multiple escape sequences: each "set clipboard" sequence a terminal
+// receives replaces the clipboard outright, so writing several chunks
+// back to back just leaves the clipboard holding whatever the last
+// (non-self-contained) chunk decodes to. Rather than silently corrupt
+// the clipboard, Inject rejects payloads whose base64 encoding exceeds
+// maxBytes. There is no optional chunking mode: it was dropped as
+// infeasible rather than implemented and left off by default, so don't
+// expect a toggle to turn it on.
+type osc52Backend struct {
+	maxBytes int
+}
+
+// NewOsc52Backend builds a Backend that writes OSC 52 escape sequences to
+// the terminal. maxBytes caps the base64 payload size per write (0 uses
+// defaultOsc52MaxBytes); text whose encoded size exceeds maxBytes is
+// rejected.
+func NewOsc52Backend(maxBytes int) Backend {
+	if maxBytes <= 0 {
+		maxBytes = defaultOsc52MaxBytes
+	}
+	return &osc52Backend{maxBytes: maxBytes}
+}
+
+func (b *osc52Backend) Name() string {
+	return "osc52"
+}
+
+func (b *osc52Backend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tty, err := osc52TTY()
+	if err != nil {
+		return fmt.Errorf("osc52: %w", err)
+	}
+	defer tty.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = tty.SetWriteDeadline(deadline)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	if len(encoded) > b.maxBytes {
+		return fmt.Errorf("osc52: encoded payload is %d bytes, exceeds Osc52MaxBytes=%d", len(encoded), b.maxBytes)
+	}
+
+	return writeOsc52(tty, encoded)
+}
+
+func writeOsc52(w ttyWriter, encoded string) error {
+	_, err := w.Write([]byte(osc52Prefix + encoded + osc52Suffix))
+	if err != nil {
+		return fmt.Errorf("osc52: write failed: %w", err)
+	}
+	return nil
+}
+
+// ttyWriter is the subset of *os.File that writeOsc52 needs, small enough
+// for tests to fake with a pty.
+type ttyWriter interface {
+	Write(p []byte) (int, error)
+}
+
+func osc52TTY() (*os.File, error) {
+	if path := os.Getenv("HYPRVOICE_OSC52_TTY"); path != "" {
+		return os.OpenFile(path, os.O_WRONLY, 0)
+	}
+	return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+}
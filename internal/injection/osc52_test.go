@@ -0,0 +1,71 @@
+package injection
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+func withOsc52TTY(t *testing.T) *os.File {
+	t.Helper()
+	master, slave, err := pty.Open()
+	if err != nil {
+		t.Skipf("pty not available in this environment: %v", err)
+	}
+
+	t.Setenv("HYPRVOICE_OSC52_TTY", slave.Name())
+	t.Cleanup(func() {
+		slave.Close()
+		master.Close()
+	})
+	return master
+}
+
+func readAvailable(t *testing.T, f *os.File, want int) []byte {
+	t.Helper()
+	_ = f.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 0, want)
+	tmp := make([]byte, 4096)
+	for len(buf) < want {
+		n, err := f.Read(tmp)
+		buf = append(buf, tmp[:n]...)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatalf("read: %v", err)
+		}
+	}
+	return buf
+}
+
+func TestOsc52BackendWritesEscapeSequence(t *testing.T) {
+	master := withOsc52TTY(t)
+
+	backend := NewOsc52Backend(0)
+	if err := backend.Inject(context.Background(), "hello", time.Second); err != nil {
+		t.Fatalf("Inject() error = %v", err)
+	}
+
+	want := osc52Prefix + base64.StdEncoding.EncodeToString([]byte("hello")) + osc52Suffix
+	got := string(readAvailable(t, master, len(want)))
+	if got != want {
+		t.Fatalf("wrote %q, want %q", got, want)
+	}
+}
+
+func TestOsc52BackendRejectsOversizePayload(t *testing.T) {
+	withOsc52TTY(t)
+
+	backend := NewOsc52Backend(16)
+	err := backend.Inject(context.Background(), strings.Repeat("x", 64), time.Second)
+	if err == nil {
+		t.Fatal("Inject() error = nil, want oversize-payload error")
+	}
+}
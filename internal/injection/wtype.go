@@ -0,0 +1,44 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// wtypeBackend types text via wtype, a Wayland virtual-keyboard typing
+// tool that works without root or uinput access on compositors that
+// support the protocol.
+type wtypeBackend struct{}
+
+// NewWtypeBackend builds a Backend that types text using wtype.
+func NewWtypeBackend() Backend {
+	return &wtypeBackend{}
+}
+
+func (b *wtypeBackend) Name() string {
+	return "wtype"
+}
+
+func (b *wtypeBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path, err := exec.LookPath("wtype")
+	if err != nil {
+		return fmt.Errorf("wtype: not found on $PATH: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, path, text)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("wtype: failed: %w: %s", err, stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// ydotoolBackend types text via ydotool, the input-injection tool that
+// works on Wayland compositors through the uinput kernel interface.
+type ydotoolBackend struct{}
+
+// NewYdotoolBackend builds a Backend that types text using ydotool.
+func NewYdotoolBackend() Backend {
+	return &ydotoolBackend{}
+}
+
+func (b *ydotoolBackend) Name() string {
+	return "ydotool"
+}
+
+func (b *ydotoolBackend) Inject(ctx context.Context, text string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path, err := exec.LookPath("ydotool")
+	if err != nil {
+		return fmt.Errorf("ydotool: not found on $PATH: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, path, "type", "--", text)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ydotool: failed: %w: %s", err, stderr.String())
+	}
+
+	return nil
+}